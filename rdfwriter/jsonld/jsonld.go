@@ -0,0 +1,103 @@
+// Package jsonld implements rdfwriter.TripleSink for JSON-LD: triples are
+// grouped by subject into "@id" objects, with an "@context" derived from the
+// document's namespace prefixes.
+package jsonld
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/RishabhBhatnagar/gordf/rdfloader/parser"
+	"github.com/RishabhBhatnagar/gordf/uri"
+)
+
+// JSONLDSink collects triples grouped by subject and writes them out as a
+// single JSON-LD document on EndDocument. JSON-LD's single top-level graph
+// forces buffering, so unlike NTriplesSink it can't write incrementally --
+// but it implements rdfwriter.TripleSink so callers can swap it in without
+// changing their writer-driving code.
+type JSONLDSink struct {
+	w         io.Writer
+	context   map[string]string
+	bySubject map[*parser.Node]map[string]interface{}
+	order     []*parser.Node
+}
+
+// NewJSONLDSink returns a sink that writes to w.
+func NewJSONLDSink(w io.Writer) *JSONLDSink {
+	return &JSONLDSink{
+		w:         w,
+		bySubject: make(map[*parser.Node]map[string]interface{}),
+	}
+}
+
+// StartDocument builds the "@context" object directly from the namespace
+// prefixes: a JSON-LD context maps term/prefix -> IRI, the opposite
+// direction from rdfwriter.InvertSchemaDefinition's URI -> abbreviation map.
+func (s *JSONLDSink) StartDocument(prefixes map[string]uri.URIRef) error {
+	context := make(map[string]string, len(prefixes))
+	for abbreviation, namespace := range prefixes {
+		context[abbreviation] = namespace.String()
+	}
+	s.context = context
+	return nil
+}
+
+// WriteTriple adds t's predicate/object to the JSON object being built for
+// t.Subject, creating that object the first time the subject is seen. A
+// predicate seen more than once for the same subject becomes a JSON array.
+func (s *JSONLDSink) WriteTriple(t *parser.Triple) error {
+	object, exists := s.bySubject[t.Subject]
+	if !exists {
+		object = map[string]interface{}{"@id": formatNode(t.Subject)}
+		s.bySubject[t.Subject] = object
+		s.order = append(s.order, t.Subject)
+	}
+
+	predicate := formatNode(t.Predicate)
+	value := formatValue(t.Object)
+	switch existing := object[predicate].(type) {
+	case nil:
+		object[predicate] = value
+	case []interface{}:
+		object[predicate] = append(existing, value)
+	default:
+		object[predicate] = []interface{}{existing, value}
+	}
+	return nil
+}
+
+// EndDocument assembles the "@context" and the subject objects into a
+// single JSON-LD document and writes it to the underlying writer.
+func (s *JSONLDSink) EndDocument() error {
+	graph := make([]map[string]interface{}, 0, len(s.order))
+	for _, subject := range s.order {
+		graph = append(graph, s.bySubject[subject])
+	}
+
+	document := map[string]interface{}{
+		"@context": s.context,
+		"@graph":   graph,
+	}
+	encoder := json.NewEncoder(s.w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(document)
+}
+
+// formatNode renders a node as a JSON-LD string value: the bare IRI, or
+// "_:id" for a blank node.
+func formatNode(node *parser.Node) string {
+	if node.NodeType == parser.BLANK {
+		return "_:" + node.ID
+	}
+	return node.ID
+}
+
+// formatValue renders an object node as a JSON-LD value: a plain string for
+// a literal, an "@id" reference for an IRI or blank node.
+func formatValue(node *parser.Node) interface{} {
+	if node.NodeType == parser.LITERAL {
+		return node.ID
+	}
+	return map[string]interface{}{"@id": formatNode(node)}
+}
@@ -0,0 +1,75 @@
+package jsonld
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/RishabhBhatnagar/gordf/rdfloader/parser"
+	"github.com/RishabhBhatnagar/gordf/uri"
+)
+
+func TestJSONLDSink(t *testing.T) {
+	// TestCase 1: an empty document still produces a valid, empty graph.
+	var buf bytes.Buffer
+	sink := NewJSONLDSink(&buf)
+	if err := sink.StartDocument(nil); err != nil {
+		t.Fatalf("unexpected error starting an empty document: %v", err)
+	}
+	if err := sink.EndDocument(); err != nil {
+		t.Fatalf("unexpected error ending an empty document: %v", err)
+	}
+
+	var empty map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &empty); err != nil {
+		t.Fatalf("empty document is not valid JSON: %v", err)
+	}
+	if graph, _ := empty["@graph"].([]interface{}); len(graph) != 0 {
+		t.Errorf("expected an empty @graph, found %v", empty["@graph"])
+	}
+
+	// TestCase 2: the "@context" maps the prefix to the IRI (not the other
+	// way around), and a triple's subject/predicate/object are grouped
+	// correctly under one "@id" object.
+	buf.Reset()
+	var namespace uri.URIRef
+	prefixes := map[string]uri.URIRef{"rdf": namespace}
+
+	blankNodeGetter := parser.BlankNodeGetter{}
+	subject := blankNodeGetter.Get()
+	predicate := &parser.Node{NodeType: parser.IRI, ID: "http://example.org/name"}
+	object := &parser.Node{NodeType: parser.LITERAL, ID: "Alice"}
+
+	sink = NewJSONLDSink(&buf)
+	if err := sink.StartDocument(prefixes); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := sink.WriteTriple(&parser.Triple{Subject: &subject, Predicate: predicate, Object: object}); err != nil {
+		t.Fatalf("unexpected error writing a triple: %v", err)
+	}
+	if err := sink.EndDocument(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var document map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &document); err != nil {
+		t.Fatalf("document is not valid JSON: %v", err)
+	}
+
+	context, _ := document["@context"].(map[string]interface{})
+	if context["rdf"] != namespace.String() {
+		t.Errorf(`expected @context["rdf"] to be the namespace IRI %q, found %v`, namespace.String(), context["rdf"])
+	}
+
+	graph, _ := document["@graph"].([]interface{})
+	if len(graph) != 1 {
+		t.Fatalf("expected exactly one subject object, found %v", graph)
+	}
+	subjectObject, _ := graph[0].(map[string]interface{})
+	if subjectObject["@id"] != "_:"+subject.ID {
+		t.Errorf(`expected subject object "@id" to be %q, found %v`, "_:"+subject.ID, subjectObject["@id"])
+	}
+	if subjectObject[predicate.ID] != "Alice" {
+		t.Errorf("expected the predicate to map to the literal value, found %v", subjectObject[predicate.ID])
+	}
+}
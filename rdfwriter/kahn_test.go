@@ -0,0 +1,91 @@
+package rdfwriter
+
+import (
+	"testing"
+
+	"github.com/RishabhBhatnagar/gordf/rdfloader/parser"
+)
+
+func TestKahnSortTriples(t *testing.T) {
+	// TestCase 1: empty input sorts to an empty, error-free output.
+	sortedTriples, err := KahnSortTriples([]*parser.Triple{})
+	if err != nil {
+		t.Errorf("unexpected error sorting an empty graph: %v", err)
+	}
+	if len(sortedTriples) != 0 {
+		t.Errorf("expected no triples, found %v", sortedTriples)
+	}
+
+	// TestCase 2: the same three-stage graph Test_topologicalSortHelper
+	// draws:
+	//                          (N1)
+	//                 (N0) ------------> (N2)
+	//                  |                  |
+	//              (N3)|                  |(N6)
+	//                  v                  v
+	//                 (N4) ------------> (N7)
+	//                          (N5)
+	nodes := getNBlankNodes(8)
+	triples := []*parser.Triple{
+		{Subject: nodes[0], Predicate: nodes[1], Object: nodes[2]},
+		{Subject: nodes[2], Predicate: nodes[6], Object: nodes[7]},
+		{Subject: nodes[0], Predicate: nodes[3], Object: nodes[4]},
+		{Subject: nodes[4], Predicate: nodes[5], Object: nodes[7]},
+	}
+	sortedTriples, err = KahnSortTriples(triples)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if len(sortedTriples) != len(triples) {
+		t.Errorf("expected all %v triples to be sorted, found %v", len(triples), len(sortedTriples))
+	}
+	// every subject must be emitted before any triple it is itself the
+	// object of.
+	position := make(map[*parser.Node]int, len(sortedTriples))
+	for i, triple := range sortedTriples {
+		if _, seen := position[triple.Subject]; !seen {
+			position[triple.Subject] = i
+		}
+	}
+	for _, triple := range sortedTriples {
+		if objectPos, seen := position[triple.Object]; seen && objectPos < position[triple.Subject] {
+			t.Errorf("object %v sorted before its subject %v", *triple.Object, *triple.Subject)
+		}
+	}
+
+	// TestCase 3: a cycle should report an error and the partial order of
+	// whatever triples did reach in-degree zero.
+	nodes = getNBlankNodes(3)
+	cyclicTriples := []*parser.Triple{
+		{Subject: nodes[0], Predicate: nodes[1], Object: nodes[1]},
+		{Subject: nodes[1], Predicate: nodes[2], Object: nodes[0]},
+	}
+	sortedTriples, err = KahnSortTriples(cyclicTriples)
+	if err == nil {
+		t.Error("expected an error reporting a cycle")
+	}
+	if len(sortedTriples) != 0 {
+		t.Errorf("expected no triple to reach in-degree zero in a 2-node cycle, found %v", sortedTriples)
+	}
+
+	// TestCase 4: output order is stable across repeated runs on the same
+	// input, regardless of map-iteration order.
+	first, err := KahnSortTriples(triples)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		again, err := KahnSortTriples(triples)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(again) != len(first) {
+			t.Fatalf("sort produced a different number of triples across runs")
+		}
+		for j := range first {
+			if first[j] != again[j] {
+				t.Errorf("KahnSortTriples is not deterministic: run %v differs from the first run at index %v", i, j)
+			}
+		}
+	}
+}
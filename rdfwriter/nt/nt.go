@@ -0,0 +1,55 @@
+// Package nt implements rdfwriter.TripleSink for the N-Triples format: one
+// escaped "subject predicate object ." line per triple, with no grouping or
+// shorthand.
+package nt
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"github.com/RishabhBhatnagar/gordf/rdfloader/parser"
+	"github.com/RishabhBhatnagar/gordf/rdfwriter"
+	"github.com/RishabhBhatnagar/gordf/uri"
+)
+
+// NTriplesSink writes triples to an underlying io.Writer in N-Triples
+// syntax, one triple per line.
+type NTriplesSink struct {
+	w *bufio.Writer
+}
+
+// NewNTriplesSink returns a sink that writes to w.
+func NewNTriplesSink(w io.Writer) *NTriplesSink {
+	return &NTriplesSink{w: bufio.NewWriter(w)}
+}
+
+// StartDocument is a no-op: N-Triples has no header or namespace prefixes,
+// every node is written out in full on its own line.
+func (s *NTriplesSink) StartDocument(prefixes map[string]uri.URIRef) error {
+	return nil
+}
+
+// WriteTriple writes a single "subject predicate object ." line.
+func (s *NTriplesSink) WriteTriple(t *parser.Triple) error {
+	_, err := fmt.Fprintf(s.w, "%s %s %s .\n", formatNode(t.Subject), formatNode(t.Predicate), formatNode(t.Object))
+	return err
+}
+
+// EndDocument flushes the buffered output to the underlying writer.
+func (s *NTriplesSink) EndDocument() error {
+	return s.w.Flush()
+}
+
+// formatNode renders a node the way N-Triples expects it: "<iri>" for an
+// IRI, "_:id" for a blank node, and an escaped, quoted string for a literal.
+func formatNode(node *parser.Node) string {
+	switch node.NodeType {
+	case parser.BLANK:
+		return "_:" + node.ID
+	case parser.LITERAL:
+		return `"` + rdfwriter.EscapeLiteral(node.ID) + `"`
+	default:
+		return "<" + node.ID + ">"
+	}
+}
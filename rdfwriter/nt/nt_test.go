@@ -0,0 +1,68 @@
+package nt
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/RishabhBhatnagar/gordf/rdfloader/parser"
+)
+
+func TestNTriplesSink(t *testing.T) {
+	// TestCase 1: an empty document (no WriteTriple calls) produces no
+	// output.
+	var buf bytes.Buffer
+	sink := NewNTriplesSink(&buf)
+	if err := sink.StartDocument(nil); err != nil {
+		t.Fatalf("unexpected error starting an empty document: %v", err)
+	}
+	if err := sink.EndDocument(); err != nil {
+		t.Fatalf("unexpected error ending an empty document: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected no output for an empty document, found %q", buf.String())
+	}
+
+	// TestCase 2: a triple with a blank-node subject, an IRI predicate and a
+	// plain literal object writes one "subject predicate object ." line.
+	buf.Reset()
+	blankNodeGetter := parser.BlankNodeGetter{}
+	subject := blankNodeGetter.Get()
+	predicate := &parser.Node{NodeType: parser.IRI, ID: "http://example.org/knows"}
+	object := &parser.Node{NodeType: parser.LITERAL, ID: "plain value"}
+
+	sink = NewNTriplesSink(&buf)
+	if err := sink.StartDocument(nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := sink.WriteTriple(&parser.Triple{Subject: &subject, Predicate: predicate, Object: object}); err != nil {
+		t.Fatalf("unexpected error writing a triple: %v", err)
+	}
+	if err := sink.EndDocument(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := buf.String()
+	if !strings.HasPrefix(output, "_:"+subject.ID+" <http://example.org/knows> \"plain value\" .\n") {
+		t.Errorf("unexpected N-Triples output: %q", output)
+	}
+
+	// TestCase 3: a literal containing a quote and a newline must come out
+	// escaped, not as a syntactically invalid line.
+	buf.Reset()
+	escaped := &parser.Node{NodeType: parser.LITERAL, ID: "line one\nsaid \"hi\""}
+	sink = NewNTriplesSink(&buf)
+	_ = sink.StartDocument(nil)
+	if err := sink.WriteTriple(&parser.Triple{Subject: &subject, Predicate: predicate, Object: escaped}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = sink.EndDocument()
+
+	output = buf.String()
+	if strings.Contains(output, "\nsaid") {
+		t.Errorf("literal newline was not escaped: %q", output)
+	}
+	if !strings.Contains(output, `\"hi\"`) {
+		t.Errorf("literal quotes were not escaped: %q", output)
+	}
+}
@@ -0,0 +1,52 @@
+package rdfwriter
+
+import "github.com/RishabhBhatnagar/gordf/rdfloader/parser"
+
+// Reachable returns every node reachable from roots by following
+// subject->object edges, roots themselves included. Order is by node ID, so
+// the result is stable across runs.
+func Reachable(triples []*parser.Triple, roots []*parser.Node) []*parser.Node {
+	adjList, _ := getAdjacencyList(triples)
+
+	visited := nodeSet{}
+	var queue []*parser.Node
+	for _, root := range roots {
+		if !visited.has(root) {
+			visited.add(root)
+			queue = append(queue, root)
+		}
+	}
+
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for neighbor := range adjList[node] {
+			if !visited.has(neighbor) {
+				visited.add(neighbor)
+				queue = append(queue, neighbor)
+			}
+		}
+	}
+	return visited.sorted()
+}
+
+// SubgraphFrom returns every triple whose subject is reachable from roots --
+// the transitive closure of roots, dropping every triple that isn't part of
+// it. It's the building block for "serialize just this subject and
+// everything under it" use-cases such as emitting a single SPDX package as
+// standalone RDF/XML: SubgraphFrom(triples, roots) piped into
+// TopologicalSortTriples and then a writer is the whole pipeline.
+func SubgraphFrom(triples []*parser.Triple, roots []*parser.Node) []*parser.Triple {
+	visited := nodeSet{}
+	for _, node := range Reachable(triples, roots) {
+		visited.add(node)
+	}
+
+	var subgraph []*parser.Triple
+	for _, triple := range triples {
+		if visited.has(triple.Subject) {
+			subgraph = append(subgraph, triple)
+		}
+	}
+	return subgraph
+}
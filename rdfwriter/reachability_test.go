@@ -0,0 +1,94 @@
+package rdfwriter
+
+import (
+	"testing"
+
+	"github.com/RishabhBhatnagar/gordf/rdfloader/parser"
+)
+
+func TestReachable(t *testing.T) {
+	// TestCase 1: empty input has nothing reachable from anywhere.
+	if reachable := Reachable([]*parser.Triple{}, nil); len(reachable) != 0 {
+		t.Errorf("expected nothing reachable from an empty graph, found %v", reachable)
+	}
+
+	// TestCase 2: (N0) -> (N1) -> (N2), with (N3) an orphan. Starting from
+	// N0 should reach N0, N1, N2 but never N3.
+	nodes := getNBlankNodes(4)
+	predicate := getNBlankNodes(1)[0]
+	triples := []*parser.Triple{
+		{Subject: nodes[0], Predicate: predicate, Object: nodes[1]},
+		{Subject: nodes[1], Predicate: predicate, Object: nodes[2]},
+	}
+	reachable := Reachable(triples, []*parser.Node{nodes[0]})
+	if len(reachable) != 3 {
+		t.Errorf("expected 3 reachable nodes, found %v: %v", len(reachable), reachable)
+	}
+	for _, want := range []*parser.Node{nodes[0], nodes[1], nodes[2]} {
+		found := false
+		for _, got := range reachable {
+			if got == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("expected %v to be reachable from the root", *want)
+		}
+	}
+	for _, got := range reachable {
+		if got == nodes[3] {
+			t.Errorf("orphan node %v should not be reachable", *nodes[3])
+		}
+	}
+
+	// TestCase 3: a cycle doesn't stop the BFS from terminating, and every
+	// node on the cycle is still reported as reachable exactly once.
+	nodes = getNBlankNodes(3)
+	cyclic := []*parser.Triple{
+		{Subject: nodes[0], Predicate: predicate, Object: nodes[1]},
+		{Subject: nodes[1], Predicate: predicate, Object: nodes[2]},
+		{Subject: nodes[2], Predicate: predicate, Object: nodes[0]},
+	}
+	reachable = Reachable(cyclic, []*parser.Node{nodes[0]})
+	if len(reachable) != 3 {
+		t.Errorf("expected 3 reachable nodes in a 3-node cycle, found %v: %v", len(reachable), reachable)
+	}
+}
+
+func TestSubgraphFrom(t *testing.T) {
+	// TestCase 1: empty input has no subgraph.
+	if subgraph := SubgraphFrom([]*parser.Triple{}, nil); len(subgraph) != 0 {
+		t.Errorf("expected an empty subgraph, found %v", subgraph)
+	}
+
+	// TestCase 2: (N0) -> (N1) -> (N2) is the root's subgraph; an unrelated
+	// (N3) -> (N4) triple must be dropped.
+	nodes := getNBlankNodes(5)
+	predicate := getNBlankNodes(1)[0]
+	triples := []*parser.Triple{
+		{Subject: nodes[0], Predicate: predicate, Object: nodes[1]},
+		{Subject: nodes[1], Predicate: predicate, Object: nodes[2]},
+		{Subject: nodes[3], Predicate: predicate, Object: nodes[4]},
+	}
+	subgraph := SubgraphFrom(triples, []*parser.Node{nodes[0]})
+	if len(subgraph) != 2 {
+		t.Errorf("expected 2 triples in the subgraph, found %v: %v", len(subgraph), subgraph)
+	}
+	for _, triple := range subgraph {
+		if triple.Subject == nodes[3] {
+			t.Errorf("unrelated triple %v should not be part of the subgraph", *triple)
+		}
+	}
+
+	// TestCase 3: SubgraphFrom -> TopologicalSortTriples, the pipeline this
+	// function exists to simplify, should still produce a validly ordered,
+	// complete result for the root's subgraph.
+	sorted, err := TopologicalSortTriples(subgraph)
+	if err != nil {
+		t.Errorf("unexpected error sorting the subgraph: %v", err)
+	}
+	if len(sorted) != len(subgraph) {
+		t.Errorf("expected all %v subgraph triples to be sorted, found %v", len(subgraph), len(sorted))
+	}
+}
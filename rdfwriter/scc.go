@@ -0,0 +1,154 @@
+package rdfwriter
+
+import (
+	"fmt"
+
+	"github.com/RishabhBhatnagar/gordf/rdfloader/parser"
+)
+
+// tarjanState bundles the bookkeeping Tarjan's algorithm needs while it
+// walks the triple graph: the running index/lowlink counters, the stack of
+// nodes not yet assigned to a component, and the components found so far.
+type tarjanState struct {
+	adjList map[*parser.Node]nodeSet
+	index   map[*parser.Node]int
+	lowlink map[*parser.Node]int
+	onStack map[*parser.Node]bool
+	stack   []*parser.Node
+	counter int
+	sccs    [][]*parser.Node
+}
+
+// StronglyConnectedComponents decomposes the triple graph into its strongly
+// connected components using Tarjan's algorithm. Every node appears in
+// exactly one component: a singleton component whose node isn't its own
+// neighbor is part of an already-acyclic region of the graph, while a
+// component with more than one node (or a single self-looping node) is a
+// cycle that TopologicalSortTriples can't linearise on its own.
+func StronglyConnectedComponents(triples []*parser.Triple) [][]*parser.Node {
+	adjList, _ := getAdjacencyList(triples)
+	state := &tarjanState{
+		adjList: adjList,
+		index:   make(map[*parser.Node]int, len(adjList)),
+		lowlink: make(map[*parser.Node]int, len(adjList)),
+		onStack: make(map[*parser.Node]bool, len(adjList)),
+	}
+
+	// walk the nodes in a deterministic order so that the component order
+	// doesn't depend on map-iteration order.
+	nodes := make([]*parser.Node, 0, len(adjList))
+	for node := range adjList {
+		nodes = append(nodes, node)
+	}
+	sortNodesByID(nodes)
+
+	for _, node := range nodes {
+		if _, visited := state.index[node]; !visited {
+			state.strongConnect(node)
+		}
+	}
+	return state.sccs
+}
+
+// strongConnect runs the recursive part of Tarjan's algorithm rooted at v.
+func (s *tarjanState) strongConnect(v *parser.Node) {
+	s.index[v] = s.counter
+	s.lowlink[v] = s.counter
+	s.counter++
+	s.stack = append(s.stack, v)
+	s.onStack[v] = true
+
+	for w := range s.adjList[v] {
+		if _, visited := s.index[w]; !visited {
+			s.strongConnect(w)
+			s.lowlink[v] = min(s.lowlink[v], s.lowlink[w])
+		} else if s.onStack[w] {
+			s.lowlink[v] = min(s.lowlink[v], s.index[w])
+		}
+	}
+
+	// v is the root of its component iff its lowlink never dipped below its
+	// own index: pop the stack down to (and including) v to collect it.
+	if s.lowlink[v] == s.index[v] {
+		var scc []*parser.Node
+		for {
+			n := len(s.stack) - 1
+			w := s.stack[n]
+			s.stack = s.stack[:n]
+			s.onStack[w] = false
+			scc = append(scc, w)
+			if w == v {
+				break
+			}
+		}
+		s.sccs = append(s.sccs, scc)
+	}
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// TopologicalSortTriplesBySCC condenses each strongly connected component of
+// the triple graph into a single super-node, topologically sorts the
+// resulting condensation DAG (which is always acyclic -- that's the point of
+// condensing on SCCs), and emits the original triples SCC by SCC following
+// that order. Where TopologicalSortTriples and TopologicalSortTriplesStrict
+// both give up on (or merely flag) a cyclic graph, this function always
+// returns a complete ordering: within a multi-node SCC there's no valid
+// subject-before-object order to find, so a downstream xmlwriter is expected
+// to fall back to rdf:nodeID blank-node references there instead of relying
+// on triple order.
+func TopologicalSortTriplesBySCC(triples []*parser.Triple) (sortedTriples []*parser.Triple, err error) {
+	adjList, recoveryDS := getAdjacencyList(triples)
+	sccs := StronglyConnectedComponents(triples)
+
+	// map every node to the representative (first, by ID) node of its SCC.
+	sccOf := make(map[*parser.Node]*parser.Node, len(adjList))
+	for _, scc := range sccs {
+		sortNodesByID(scc)
+		representative := scc[0]
+		for _, node := range scc {
+			sccOf[node] = representative
+		}
+	}
+
+	// build the condensation DAG: an edge from one SCC's representative to
+	// another's whenever an edge crosses between them in the original graph.
+	// nodeSet takes care of deduping repeated cross-SCC edges.
+	condensedAdjList := make(map[*parser.Node]nodeSet, len(sccs))
+	for node, neighbors := range adjList {
+		from := sccOf[node]
+		if condensedAdjList[from] == nil {
+			condensedAdjList[from] = nodeSet{}
+		}
+		for neighbor := range neighbors {
+			to := sccOf[neighbor]
+			if to != from {
+				condensedAdjList[from].add(to)
+			}
+		}
+	}
+
+	sortedRepresentatives, err := topologicalSort(condensedAdjList)
+	if err != nil {
+		return nil, fmt.Errorf("error sorting the condensation DAG: %v", err)
+	}
+
+	sccByRepresentative := make(map[*parser.Node][]*parser.Node, len(sccs))
+	for _, scc := range sccs {
+		sccByRepresentative[scc[0]] = scc
+	}
+
+	// topologicalSort returns the reverse order (objects before subjects), so
+	// walk it back to front to emit subjects before their objects.
+	for i := len(sortedRepresentatives) - 1; i >= 0; i-- {
+		for _, node := range sccByRepresentative[sortedRepresentatives[i]] {
+			sortedTriples = append(sortedTriples, recoveryDS[node]...)
+		}
+	}
+	return sortedTriples, nil
+}
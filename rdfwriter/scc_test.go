@@ -0,0 +1,96 @@
+package rdfwriter
+
+import (
+	"testing"
+
+	"github.com/RishabhBhatnagar/gordf/rdfloader/parser"
+)
+
+func TestStronglyConnectedComponents(t *testing.T) {
+	// TestCase 1: empty input has no components.
+	sccs := StronglyConnectedComponents([]*parser.Triple{})
+	if len(sccs) != 0 {
+		t.Errorf("expected no components, found %v", sccs)
+	}
+
+	// TestCase 2: an acyclic graph has exactly one node per component.
+	nodes := getNBlankNodes(3)
+	predicate := getNBlankNodes(1)[0]
+	acyclic := []*parser.Triple{
+		{Subject: nodes[0], Predicate: predicate, Object: nodes[1]},
+		{Subject: nodes[1], Predicate: predicate, Object: nodes[2]},
+	}
+	sccs = StronglyConnectedComponents(acyclic)
+	if len(sccs) != 3 {
+		t.Errorf("expected 3 singleton components, found %v components: %v", len(sccs), sccs)
+	}
+	for _, scc := range sccs {
+		if len(scc) != 1 {
+			t.Errorf("expected a singleton component in an acyclic graph, found %v", scc)
+		}
+	}
+
+	// TestCase 3: a 4-node cycle (N0)->(N1)->(N2)->(N3)->(N0) is one
+	// component containing all 4 nodes.
+	nodes = getNBlankNodes(4)
+	cyclic := []*parser.Triple{
+		{Subject: nodes[0], Predicate: predicate, Object: nodes[1]},
+		{Subject: nodes[1], Predicate: predicate, Object: nodes[2]},
+		{Subject: nodes[2], Predicate: predicate, Object: nodes[3]},
+		{Subject: nodes[3], Predicate: predicate, Object: nodes[0]},
+	}
+	sccs = StronglyConnectedComponents(cyclic)
+	if len(sccs) != 1 {
+		t.Errorf("expected exactly 1 component, found %v: %v", len(sccs), sccs)
+	} else if len(sccs[0]) != 4 {
+		t.Errorf("expected the component to contain all 4 nodes, found %v", sccs[0])
+	}
+}
+
+func TestTopologicalSortTriplesBySCC(t *testing.T) {
+	// TestCase 1: empty input sorts to an empty, error-free output.
+	sortedTriples, err := TopologicalSortTriplesBySCC([]*parser.Triple{})
+	if err != nil {
+		t.Errorf("unexpected error sorting an empty graph: %v", err)
+	}
+	if len(sortedTriples) != 0 {
+		t.Errorf("expected no triples, found %v", sortedTriples)
+	}
+
+	// TestCase 2: an acyclic graph sorts to exactly as many triples as went
+	// in, subject before object.
+	nodes := getNBlankNodes(3)
+	predicate := getNBlankNodes(1)[0]
+	acyclic := []*parser.Triple{
+		{Subject: nodes[0], Predicate: predicate, Object: nodes[1]},
+		{Subject: nodes[1], Predicate: predicate, Object: nodes[2]},
+	}
+	sortedTriples, err = TopologicalSortTriplesBySCC(acyclic)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if len(sortedTriples) != len(acyclic) {
+		t.Errorf("expected all %v triples to be sorted, found %v", len(acyclic), len(sortedTriples))
+	}
+	if sortedTriples[0].Subject != nodes[0] || sortedTriples[1].Subject != nodes[1] {
+		t.Errorf("expected triples ordered subject-before-object, found %v", sortedTriples)
+	}
+
+	// TestCase 3: unlike TopologicalSortTriples, a cyclic graph is still
+	// fully ordered: a cycle condenses into a single super-node instead of
+	// producing an error.
+	nodes = getNBlankNodes(4)
+	cyclic := []*parser.Triple{
+		{Subject: nodes[0], Predicate: predicate, Object: nodes[1]},
+		{Subject: nodes[1], Predicate: predicate, Object: nodes[2]},
+		{Subject: nodes[2], Predicate: predicate, Object: nodes[3]},
+		{Subject: nodes[3], Predicate: predicate, Object: nodes[0]},
+	}
+	sortedTriples, err = TopologicalSortTriplesBySCC(cyclic)
+	if err != nil {
+		t.Errorf("unexpected error sorting a cyclic graph: %v", err)
+	}
+	if len(sortedTriples) != len(cyclic) {
+		t.Errorf("expected all %v triples to be present, found %v", len(cyclic), len(sortedTriples))
+	}
+}
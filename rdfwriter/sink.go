@@ -0,0 +1,54 @@
+package rdfwriter
+
+import (
+	"strings"
+
+	"github.com/RishabhBhatnagar/gordf/rdfloader/parser"
+	"github.com/RishabhBhatnagar/gordf/uri"
+)
+
+// TripleSink is the interface every streaming RDF serializer in this module
+// implements, so a writer can push triples out to N-Triples, Turtle, or
+// JSON-LD through the same calling convention instead of forcing every
+// caller to special-case the target format.
+// Callers are expected to call StartDocument once, WriteTriple once per
+// triple -- ideally in the order TopologicalSortTriples (or one of its
+// variants) produces, so that blank-node references stay resolvable in
+// formats that care about forward references -- and EndDocument exactly
+// once when done.
+type TripleSink interface {
+	// StartDocument is called before the first WriteTriple call. prefixes
+	// maps namespace abbreviations (e.g. "rdf") to the URIs they stand for,
+	// the same shape schemaDefinition has for invertSchemaDefinition.
+	StartDocument(prefixes map[string]uri.URIRef) error
+
+	// WriteTriple serializes a single triple to the sink's destination.
+	WriteTriple(t *parser.Triple) error
+
+	// EndDocument flushes/finalizes the output. No further WriteTriple
+	// calls are valid after this returns.
+	EndDocument() error
+}
+
+// InvertSchemaDefinition is the exported form of invertSchemaDefinition, for
+// sink implementations outside this package that need the URI ->
+// abbreviation map of a document's namespace prefixes. rdfwriter/jsonld does
+// not use it: its "@context" maps abbreviation -> URI.
+func InvertSchemaDefinition(schemaDefinition map[string]uri.URIRef) map[string]string {
+	return invertSchemaDefinition(schemaDefinition)
+}
+
+// EscapeLiteral escapes the characters that both N-Triples and Turtle
+// require to be escaped inside a literal's quoted string, so every
+// TripleSink implementation that writes quoted literals shares one escaping
+// rule instead of each re-implementing (and risking drifting from) its own.
+func EscapeLiteral(s string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		`"`, `\"`,
+		"\n", `\n`,
+		"\r", `\r`,
+		"\t", `\t`,
+	)
+	return replacer.Replace(s)
+}
@@ -0,0 +1,132 @@
+// Package turtle implements rdfwriter.TripleSink for the Turtle format,
+// grouping triples that share a subject behind a single subject with ";"
+// between predicates and "," between objects of the same predicate.
+package turtle
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/RishabhBhatnagar/gordf/rdfloader/parser"
+	"github.com/RishabhBhatnagar/gordf/rdfwriter"
+	"github.com/RishabhBhatnagar/gordf/uri"
+)
+
+// TurtleSink writes triples to an underlying io.Writer in Turtle syntax. It
+// buffers every triple until EndDocument, grouping them by subject via
+// rdfwriter.DisjointSet so that subjects which are never referenced as
+// someone else's object (the graph's roots) are written first and nested
+// blank-node subjects follow, instead of depending on write order.
+type TurtleSink struct {
+	w         *bufio.Writer
+	triples   []*parser.Triple
+	bySubject map[*parser.Node][]*parser.Triple
+	order     []*parser.Node
+}
+
+// NewTurtleSink returns a sink that writes to w.
+func NewTurtleSink(w io.Writer) *TurtleSink {
+	return &TurtleSink{
+		w:         bufio.NewWriter(w),
+		bySubject: make(map[*parser.Node][]*parser.Triple),
+	}
+}
+
+// StartDocument emits the "@prefix" block for the document's namespaces.
+func (s *TurtleSink) StartDocument(prefixes map[string]uri.URIRef) error {
+	for abbreviation, namespace := range prefixes {
+		if _, err := fmt.Fprintf(s.w, "@prefix %s: <%s> .\n", abbreviation, namespace.String()); err != nil {
+			return err
+		}
+	}
+	if len(prefixes) > 0 {
+		if _, err := s.w.WriteString("\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteTriple buffers t under its subject; nothing is written to w until
+// EndDocument groups subjects into blocks.
+func (s *TurtleSink) WriteTriple(t *parser.Triple) error {
+	if _, exists := s.bySubject[t.Subject]; !exists {
+		s.order = append(s.order, t.Subject)
+	}
+	s.bySubject[t.Subject] = append(s.bySubject[t.Subject], t)
+	s.triples = append(s.triples, t)
+	return nil
+}
+
+// EndDocument emits one Turtle block per subject -- roots (subjects that are
+// never anyone's object, per rdfwriter.DisjointSet) first, then the rest in
+// the order they were first seen -- and flushes the buffered output.
+func (s *TurtleSink) EndDocument() error {
+	parents := rdfwriter.DisjointSet(s.triples)
+	subjects := make([]*parser.Node, len(s.order))
+	copy(subjects, s.order)
+	sort.SliceStable(subjects, func(i, j int) bool {
+		return parents[subjects[i]] == nil && parents[subjects[j]] != nil
+	})
+
+	for _, subject := range subjects {
+		if err := s.writeSubjectBlock(subject); err != nil {
+			return err
+		}
+	}
+	return s.w.Flush()
+}
+
+// writeSubjectBlock writes the Turtle block for a single subject, grouping
+// its triples by predicate so repeated predicates collapse to a "," list.
+func (s *TurtleSink) writeSubjectBlock(subject *parser.Node) error {
+	if _, err := s.w.WriteString(formatNode(subject)); err != nil {
+		return err
+	}
+
+	byPredicate := make(map[*parser.Node][]*parser.Node)
+	var predicateOrder []*parser.Node
+	for _, triple := range s.bySubject[subject] {
+		if _, exists := byPredicate[triple.Predicate]; !exists {
+			predicateOrder = append(predicateOrder, triple.Predicate)
+		}
+		byPredicate[triple.Predicate] = append(byPredicate[triple.Predicate], triple.Object)
+	}
+
+	for i, predicate := range predicateOrder {
+		separator := " "
+		if i > 0 {
+			separator = " ;\n    "
+		}
+		if _, err := fmt.Fprintf(s.w, "%s%s ", separator, formatNode(predicate)); err != nil {
+			return err
+		}
+		for j, object := range byPredicate[predicate] {
+			if j > 0 {
+				if _, err := s.w.WriteString(", "); err != nil {
+					return err
+				}
+			}
+			if _, err := s.w.WriteString(formatNode(object)); err != nil {
+				return err
+			}
+		}
+	}
+	_, err := s.w.WriteString(" .\n")
+	return err
+}
+
+// formatNode renders a node the way Turtle expects it: "<iri>" for an IRI,
+// "_:id" for a blank node, and an escaped, quoted string for a literal.
+func formatNode(node *parser.Node) string {
+	switch node.NodeType {
+	case parser.BLANK:
+		return "_:" + node.ID
+	case parser.LITERAL:
+		return `"` + rdfwriter.EscapeLiteral(node.ID) + `"`
+	default:
+		return "<" + node.ID + ">"
+	}
+}
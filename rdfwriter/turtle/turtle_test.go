@@ -0,0 +1,71 @@
+package turtle
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/RishabhBhatnagar/gordf/rdfloader/parser"
+)
+
+func TestTurtleSink(t *testing.T) {
+	// TestCase 1: an empty document (no WriteTriple calls) produces no
+	// subject blocks.
+	var buf bytes.Buffer
+	sink := NewTurtleSink(&buf)
+	if err := sink.StartDocument(nil); err != nil {
+		t.Fatalf("unexpected error starting an empty document: %v", err)
+	}
+	if err := sink.EndDocument(); err != nil {
+		t.Fatalf("unexpected error ending an empty document: %v", err)
+	}
+	if strings.Contains(buf.String(), " .") {
+		t.Errorf("expected no subject blocks for an empty document, found %q", buf.String())
+	}
+
+	// TestCase 2: two triples sharing a subject collapse into one block
+	// with a ";" between predicates.
+	buf.Reset()
+	blankNodeGetter := parser.BlankNodeGetter{}
+	subject := blankNodeGetter.Get()
+	predicateA := &parser.Node{NodeType: parser.IRI, ID: "http://example.org/name"}
+	predicateB := &parser.Node{NodeType: parser.IRI, ID: "http://example.org/age"}
+	objectA := &parser.Node{NodeType: parser.LITERAL, ID: "Alice"}
+	objectB := &parser.Node{NodeType: parser.LITERAL, ID: "30"}
+
+	sink = NewTurtleSink(&buf)
+	_ = sink.StartDocument(nil)
+	if err := sink.WriteTriple(&parser.Triple{Subject: &subject, Predicate: predicateA, Object: objectA}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := sink.WriteTriple(&parser.Triple{Subject: &subject, Predicate: predicateB, Object: objectB}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := sink.EndDocument(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := buf.String()
+	if strings.Count(output, "_:"+subject.ID) != 1 {
+		t.Errorf("expected the shared subject to be written exactly once, found: %q", output)
+	}
+	if !strings.Contains(output, " ;\n") {
+		t.Errorf("expected predicates to be joined with \" ;\", found: %q", output)
+	}
+
+	// TestCase 3: a literal containing a quote must come out escaped, not
+	// as a syntactically invalid block.
+	buf.Reset()
+	quoted := &parser.Node{NodeType: parser.LITERAL, ID: `she said "hi"`}
+	sink = NewTurtleSink(&buf)
+	_ = sink.StartDocument(nil)
+	if err := sink.WriteTriple(&parser.Triple{Subject: &subject, Predicate: predicateA, Object: quoted}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = sink.EndDocument()
+
+	output = buf.String()
+	if !strings.Contains(output, `\"hi\"`) {
+		t.Errorf("literal quotes were not escaped: %q", output)
+	}
+}
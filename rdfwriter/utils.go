@@ -4,16 +4,47 @@ import (
 	"fmt"
 	"github.com/RishabhBhatnagar/gordf/rdfloader/parser"
 	"github.com/RishabhBhatnagar/gordf/uri"
+	"sort"
 	"strings"
 )
 
+// nodeSet is a hash-set of nodes. It replaces the []*parser.Node slices the
+// adjacency list used to store its neighbors in: a subject with a large
+// fan-out (RDF lists, or an SPDX package-file relationship with thousands of
+// hasFile edges) made every dedup-on-insert and membership check on those
+// slices an O(n) scan, which made the sort O(E*V) in the worst case. A map
+// lookup keeps both operations O(1).
+type nodeSet map[*parser.Node]struct{}
+
+// add inserts node into the set. It's a no-op if node is already present.
+func (s nodeSet) add(node *parser.Node) {
+	s[node] = struct{}{}
+}
+
+// has reports whether node is in the set.
+func (s nodeSet) has(node *parser.Node) bool {
+	_, exists := s[node]
+	return exists
+}
+
+// sorted returns the set's members ordered by node ID, for callers that need
+// deterministic output (e.g. Reachable).
+func (s nodeSet) sorted() []*parser.Node {
+	nodes := make([]*parser.Node, 0, len(s))
+	for node := range s {
+		nodes = append(nodes, node)
+	}
+	sortNodesByID(nodes)
+	return nodes
+}
+
 // returns an adjacency list from a list of triples
 // Params:
 //   triples: might be unordered
 // Output:
-//    adjList: adjacency list which maps subject to object for each triple
+//    adjList: adjacency list which maps subject to the set of its objects
 //    recoveryDS: subject to triple mapping that will help retrieve the triples after sorting the Subject: Object pairs.
-func getAdjacencyList(triples []*parser.Triple) (adjList map[*parser.Node][]*parser.Node, recoveryDS map[*parser.Node][]*parser.Triple) {
+func getAdjacencyList(triples []*parser.Triple) (adjList map[*parser.Node]nodeSet, recoveryDS map[*parser.Node][]*parser.Triple) {
 	// triples are analogous to the edges of a graph.
 	// For a (Subject, Predicate, Object) triple,
 	// it forms a directed edge from Subject to Object
@@ -22,22 +53,22 @@ func getAdjacencyList(triples []*parser.Triple) (adjList map[*parser.Node][]*par
 	//             (Subject) ---------------> (Object)
 
 	// initialising the adjacency list:
-	adjList = make(map[*parser.Node][]*parser.Node)
+	adjList = make(map[*parser.Node]nodeSet)
 	recoveryDS = make(map[*parser.Node][]*parser.Triple)
 	for _, triple := range triples {
 		// create a new entry in the adjList if the key is not already seen.
 		if adjList[triple.Subject] == nil {
-			adjList[triple.Subject] = []*parser.Node{}
+			adjList[triple.Subject] = nodeSet{}
 			recoveryDS[triple.Subject] = []*parser.Triple{}
 		}
 
-		// the key is already seen and we can directly append the child
-		adjList[triple.Subject] = append(adjList[triple.Subject], triple.Object)
+		// the key is already seen; the set itself takes care of deduping.
+		adjList[triple.Subject].add(triple.Object)
 		recoveryDS[triple.Subject] = append(recoveryDS[triple.Subject], triple)
 
 		// ensure that there is a key entry for all the children.
 		if adjList[triple.Object] == nil {
-			adjList[triple.Object] = []*parser.Node{}
+			adjList[triple.Object] = nodeSet{}
 			recoveryDS[triple.Object] = []*parser.Triple{}
 		}
 	}
@@ -50,7 +81,7 @@ func getAdjacencyList(triples []*parser.Triple) (adjList map[*parser.Node][]*par
 //     lastIdx: index where a new node should be added in the resultList
 //     visited: if visited[node] is true, we've already serviced the node before.
 //     resultList: list of all the nodes after topological sorting.
-func topologicalSortHelper(node *parser.Node, lastIndex *int, adjList map[*parser.Node][]*parser.Node, visited *map[*parser.Node]bool, resultList *[]*parser.Node) (err error) {
+func topologicalSortHelper(node *parser.Node, lastIndex *int, adjList map[*parser.Node]nodeSet, visited *map[*parser.Node]bool, resultList *[]*parser.Node) (err error) {
 	if node == nil {
 		return
 	}
@@ -70,7 +101,7 @@ func topologicalSortHelper(node *parser.Node, lastIndex *int, adjList map[*parse
 	(*visited)[node] = true
 
 	// visiting all the neighbors of the node and it's children recursively
-	for _, neighbor := range adjList[node] {
+	for neighbor := range adjList[node] {
 		// recurse neighbor only if and only if it is not visited yet.
 		if !(*visited)[neighbor] {
 			err = topologicalSortHelper(neighbor, lastIndex, adjList, visited, resultList)
@@ -100,7 +131,7 @@ func topologicalSortHelper(node *parser.Node, lastIndex *int, adjList map[*parse
 //   adjList   : adjacency list: a map with key as the node and value as a
 //  			 list of it's neighbor nodes.
 // Assumes: all the nodes in the graph are present in the adjList keys.
-func topologicalSort(adjList map[*parser.Node][]*parser.Node) ([]*parser.Node, error) {
+func topologicalSort(adjList map[*parser.Node]nodeSet) ([]*parser.Node, error) {
 	// variable declaration
 	numberNodes := len(adjList)
 	resultList := make([]*parser.Node, numberNodes) //  this will be returned
@@ -146,6 +177,226 @@ func TopologicalSortTriples(triples []*parser.Triple) (sortedTriples []*parser.T
 	return sortedTriples, nil
 }
 
+// nodeColor is the three-color marking used by topologicalSortHelperStrict
+// to tell a back-edge (a cycle) apart from a forward/cross edge that simply
+// refers to a node some other branch of the DFS already finished.
+type nodeColor int
+
+const (
+	white nodeColor = iota // not yet visited
+	gray                   // on the current DFS stack
+	black                  // fully processed
+)
+
+// markCycle marks every node from the top of stack down to (and including)
+// ancestor as cyclic. A back-edge to ancestor closes a cycle through every
+// node currently between ancestor and the top of the DFS stack, not just
+// the two nodes the back-edge directly touches.
+func markCycle(stack []*parser.Node, ancestor *parser.Node, cyclicNodes map[*parser.Node]bool) {
+	for i := len(stack) - 1; i >= 0; i-- {
+		cyclicNodes[stack[i]] = true
+		if stack[i] == ancestor {
+			return
+		}
+	}
+}
+
+// topologicalSortHelperStrict is topologicalSortHelper's cycle-aware sibling.
+// Where topologicalSortHelper treats "already visited" as a single state and
+// silently returns on a repeat visit, this helper tells a finished node
+// (black) apart from a node still on the current DFS stack (gray): hitting a
+// gray node is a genuine back-edge, and every node between that ancestor and
+// the current node on the stack -- not just the two DFS-frame-local
+// endpoints -- is recorded in cyclicNodes so the caller knows exactly which
+// nodes, and in turn which triples, couldn't be placed in a valid order.
+// Params:
+//     node: current node to perform the dfs on.
+//     lastIndex: index where a new node should be added in the resultList.
+//     colors: white/gray/black state of every node seen so far.
+//     stack: nodes on the current DFS path, root-first; used to find every
+//            node a back-edge's cycle passes through.
+//     cyclicNodes: out-param; every node found to participate in a cycle is
+//                  added here.
+func topologicalSortHelperStrict(node *parser.Node, lastIndex *int, adjList map[*parser.Node]nodeSet, colors map[*parser.Node]nodeColor, resultList *[]*parser.Node, stack *[]*parser.Node, cyclicNodes map[*parser.Node]bool) (err error) {
+	if node == nil {
+		return
+	}
+
+	// checking if the node exist in the graph
+	if _, exists := adjList[node]; !exists {
+		return fmt.Errorf("node%v doesn't exist in the graph", *node)
+	}
+
+	if colors[node] == black {
+		// already fully processed by an earlier DFS tree.
+		return nil
+	}
+
+	colors[node] = gray
+	*stack = append(*stack, node)
+	for neighbor := range adjList[node] {
+		if colors[neighbor] == gray {
+			// back-edge: neighbor is an ancestor of node on the current
+			// DFS stack, i.e. every node between neighbor and node sits on
+			// the same cycle.
+			markCycle(*stack, neighbor, cyclicNodes)
+			continue
+		}
+		if colors[neighbor] == black {
+			continue
+		}
+		if err = topologicalSortHelperStrict(neighbor, lastIndex, adjList, colors, resultList, stack, cyclicNodes); err != nil {
+			return err
+		}
+	}
+	colors[node] = black
+	*stack = (*stack)[:len(*stack)-1]
+
+	if *lastIndex >= len(adjList) {
+		// there is at least one node which is a neighbor of some node
+		// whose entry doesn't exist in the adjList
+		return fmt.Errorf("found more nodes than the number of keys in the adjacency list")
+	}
+
+	// appending from left to right to get a reverse sorted output
+	(*resultList)[*lastIndex] = node
+	*lastIndex++
+	return nil
+}
+
+// topologicalSortStrict is the cycle-aware counterpart of topologicalSort:
+// it still returns every node in (reverse) topological order, but also
+// returns the set of nodes that sit on a cycle, so TopologicalSortTriplesStrict
+// can separate the triples that were genuinely ordered from the ones that
+// weren't.
+func topologicalSortStrict(adjList map[*parser.Node]nodeSet) (sortedNodes []*parser.Node, cyclicNodes map[*parser.Node]bool, err error) {
+	numberNodes := len(adjList)
+	resultList := make([]*parser.Node, numberNodes)
+	colors := make(map[*parser.Node]nodeColor, numberNodes)
+	cyclicNodes = make(map[*parser.Node]bool)
+	lastIndex := 0
+	var stack []*parser.Node
+
+	for node := range adjList {
+		if colors[node] == white {
+			if err = topologicalSortHelperStrict(node, &lastIndex, adjList, colors, &resultList, &stack, cyclicNodes); err != nil {
+				return resultList, cyclicNodes, err
+			}
+		}
+	}
+	return resultList, cyclicNodes, nil
+}
+
+// TopologicalSortTriplesStrict is TopologicalSortTriples' cycle-aware
+// sibling. TopologicalSortTriples silently folds a cycle into some arbitrary
+// order by returning early the moment it re-visits a node; that's fine for
+// an already-acyclic graph, but RDF graphs that round-trip through rdf/xml
+// often contain cycles through blank nodes, and a caller needs to know which
+// triples forced it into a fallback (e.g. re-encoding a blank-node reference
+// via rdf:nodeID) rather than silently trusting a "sorted" order.
+// Output:
+//   sortedTriples: triples whose subject/object never sat on a cycle, in
+//                  topological order.
+//   cyclicTriples: every triple whose subject or object participates in at
+//                  least one cycle. These are not repeated in sortedTriples.
+func TopologicalSortTriplesStrict(triples []*parser.Triple) (sortedTriples []*parser.Triple, cyclicTriples []*parser.Triple, err error) {
+	adjList, recoveryDS := getAdjacencyList(triples)
+	sortedNodes, cyclicNodes, err := topologicalSortStrict(adjList)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error sorting the triples: %v", err)
+	}
+
+	for _, node := range sortedNodes {
+		if cyclicNodes[node] {
+			continue
+		}
+		for _, triple := range recoveryDS[node] {
+			// a triple pointing into a cycle is reported as cyclic instead.
+			if !cyclicNodes[triple.Object] {
+				sortedTriples = append(sortedTriples, triple)
+			}
+		}
+	}
+
+	for _, triple := range triples {
+		if cyclicNodes[triple.Subject] || cyclicNodes[triple.Object] {
+			cyclicTriples = append(cyclicTriples, triple)
+		}
+	}
+	return sortedTriples, cyclicTriples, nil
+}
+
+// sortNodesByID sorts nodes in place by their ID. It exists so that any
+// caller iterating a set of nodes that's about to be observable in the
+// output (e.g. KahnSortTriples' zero-in-degree queue) gets a deterministic,
+// diff-able order instead of one that depends on Go's randomised
+// map-iteration order.
+func sortNodesByID(nodes []*parser.Node) {
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].ID < nodes[j].ID
+	})
+}
+
+// KahnSortTriples is an iterative, Kahn's-algorithm alternative to
+// TopologicalSortTriples. The recursive DFS TopologicalSortTriples relies on
+// can blow the Go stack on the long blank-node chains a round-tripped SPDX
+// document tends to produce; KahnSortTriples processes the graph with an
+// explicit queue instead of recursion, and breaks ties between nodes that
+// become ready at the same time by node ID, so its output is stable across
+// runs rather than depending on map-iteration order. It's kept alongside,
+// not instead of, TopologicalSortTriples for backward compatibility.
+// Output:
+//   sortedTriples: topologically sorted triples for the acyclic portion of
+//                  the graph.
+//   err: non-nil if the graph contains a cycle; sortedTriples then holds
+//        only the triples that could be ordered before the cycle was hit.
+func KahnSortTriples(triples []*parser.Triple) (sortedTriples []*parser.Triple, err error) {
+	adjList, recoveryDS := getAdjacencyList(triples)
+
+	// in-degree of every node, computed in one pass over the adjacency list.
+	inDegree := make(map[*parser.Node]int, len(adjList))
+	for node := range adjList {
+		inDegree[node] = 0
+	}
+	for _, neighbors := range adjList {
+		for neighbor := range neighbors {
+			inDegree[neighbor]++
+		}
+	}
+
+	// seed the queue with every node that has no incoming edge.
+	var queue []*parser.Node
+	for node, degree := range inDegree {
+		if degree == 0 {
+			queue = append(queue, node)
+		}
+	}
+	sortNodesByID(queue)
+
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		sortedTriples = append(sortedTriples, recoveryDS[node]...)
+
+		var freed []*parser.Node
+		for neighbor := range adjList[node] {
+			inDegree[neighbor]--
+			if inDegree[neighbor] == 0 {
+				freed = append(freed, neighbor)
+			}
+		}
+		sortNodesByID(freed)
+		queue = append(queue, freed...)
+	}
+
+	if len(sortedTriples) != len(triples) {
+		// some triples' subjects/objects never reached in-degree 0: the
+		// remainder of the graph is a cycle residue.
+		return sortedTriples, fmt.Errorf("graph has at least one cycle: only %v of %v triples could be ordered", len(sortedTriples), len(triples))
+	}
+	return sortedTriples, nil
+}
+
 func DisjointSet(triples []*parser.Triple) map[*parser.Node]*parser.Node {
 	parent := make(map[*parser.Node]*parser.Node)
 	for _, triple := range triples {
@@ -174,12 +425,8 @@ func invertSchemaDefinition(schemaDefinition map[string]uri.URIRef) map[string]s
 	return invertedMap
 }
 
-// return true if the target is in the given list
-func any(target string, list []string) bool {
-	for _, s := range list {
-		if s == target {
-			return true
-		}
-	}
-	return false
+// return true if the target is in the given set
+func any(target string, set map[string]struct{}) bool {
+	_, exists := set[target]
+	return exists
 }
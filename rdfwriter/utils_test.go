@@ -131,6 +131,54 @@ func TestTopologicalSortTriples(t *testing.T) {
 	}
 }
 
+func TestTopologicalSortTriplesStrict(t *testing.T) {
+	// TestCase 1: an acyclic graph has no cyclic triples at all.
+	nodes := getNBlankNodes(3)
+	triples := []*parser.Triple{
+		{Subject: nodes[0], Predicate: nodes[1], Object: nodes[2]},
+	}
+	sortedTriples, cyclicTriples, err := TopologicalSortTriplesStrict(triples)
+	if err != nil {
+		t.Errorf("unexpected error sorting an acyclic graph: %v", err)
+	}
+	if len(cyclicTriples) != 0 {
+		t.Errorf("expected no cyclic triples, found %v", cyclicTriples)
+	}
+	if len(sortedTriples) != len(triples) {
+		t.Errorf("expected all %v triples to be sorted, found %v", len(triples), len(sortedTriples))
+	}
+
+	// TestCase 2: a 4-node cycle, (N0) -> (N1) -> (N2) -> (N3) -> (N0).
+	// every node -- not just the two ends of whichever edge closes the
+	// cycle -- sits on it, so every triple must be reported as cyclic.
+	nodes = getNBlankNodes(4)
+	predicate := getNBlankNodes(1)[0]
+	cyclicInput := []*parser.Triple{
+		{Subject: nodes[0], Predicate: predicate, Object: nodes[1]},
+		{Subject: nodes[1], Predicate: predicate, Object: nodes[2]},
+		{Subject: nodes[2], Predicate: predicate, Object: nodes[3]},
+		{Subject: nodes[3], Predicate: predicate, Object: nodes[0]},
+	}
+	sortedTriples, cyclicTriples, err = TopologicalSortTriplesStrict(cyclicInput)
+	if err != nil {
+		t.Errorf("unexpected error sorting a cyclic graph: %v", err)
+	}
+	if len(sortedTriples) != 0 {
+		t.Errorf("expected no triple to be placeable outside the cycle, found %v", sortedTriples)
+	}
+	if len(cyclicTriples) != len(cyclicInput) {
+		t.Errorf("expected all %v triples to be reported as cyclic, found %v", len(cyclicInput), len(cyclicTriples))
+	}
+	// a triple reported as cyclic must not also show up in sortedTriples.
+	for _, triple := range cyclicTriples {
+		for _, other := range sortedTriples {
+			if triple == other {
+				t.Errorf("triple %v present in both sortedTriples and cyclicTriples", *triple)
+			}
+		}
+	}
+}
+
 func Test_topologicalSort(t *testing.T) {
 	nodes := getNBlankNodes(5)
 
@@ -139,8 +187,8 @@ func Test_topologicalSort(t *testing.T) {
 	// The graph is as follows:
 	//        (N1)
 	// (N0) --------> (N2)
-	adjList := map[*parser.Node][]*parser.Node{
-		nodes[0]: {nodes[2]},
+	adjList := map[*parser.Node]nodeSet{
+		nodes[0]: {nodes[2]: {}},
 	} // here, nodes[2] is child of nodes[0]
 	// but it doesn't exist in the keys of the map.
 	_, err := topologicalSort(adjList)
@@ -149,8 +197,8 @@ func Test_topologicalSort(t *testing.T) {
 	}
 
 	// TestCase 2: Valid case
-	adjList = map[*parser.Node][]*parser.Node{
-		nodes[0]: {nodes[2]},
+	adjList = map[*parser.Node]nodeSet{
+		nodes[0]: {nodes[2]: {}},
 		nodes[2]: {},
 	}
 	sortedNodes, err := topologicalSort(adjList)
@@ -166,7 +214,7 @@ func Test_topologicalSort(t *testing.T) {
 func Test_topologicalSortHelper(t *testing.T) {
 	// declaring satellite field required by the function
 	var lastIndex int
-	var adjList map[*parser.Node][]*parser.Node
+	var adjList map[*parser.Node]nodeSet
 	var visited map[*parser.Node]bool
 	var resultList []*parser.Node
 
@@ -252,3 +300,38 @@ func Test_topologicalSortHelper(t *testing.T) {
 		t.Error("order of resultList if not correct")
 	}
 }
+
+// BenchmarkTopologicalSortLargeGraph builds a synthetic graph of 100k
+// triples with a fan-out of 50 per subject and sorts it with
+// TopologicalSortTriples. Before nodeSet replaced the []*parser.Node slices
+// in the adjacency list, dedup-on-insert and membership checks against those
+// slices made this O(E*V) in the worst case; on this shape of graph that was
+// well over a second, so this benchmark exists to catch a regression back to
+// that behaviour.
+func BenchmarkTopologicalSortLargeGraph(b *testing.B) {
+	const (
+		numSubjects = 100000 / 50
+		fanOut      = 50
+	)
+	subjects := getNBlankNodes(numSubjects)
+	objects := getNBlankNodes(numSubjects * fanOut)
+	predicates := getNBlankNodes(1)
+
+	triples := make([]*parser.Triple, 0, numSubjects*fanOut)
+	for i, subject := range subjects {
+		for j := 0; j < fanOut; j++ {
+			triples = append(triples, &parser.Triple{
+				Subject:   subject,
+				Predicate: predicates[0],
+				Object:    objects[i*fanOut+j],
+			})
+		}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := TopologicalSortTriples(triples); err != nil {
+			b.Fatalf("unexpected error sorting the benchmark graph: %v", err)
+		}
+	}
+}